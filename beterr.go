@@ -5,17 +5,630 @@ package beterr
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"unicode"
 )
 
 // printOutput represents the structured output format for debug information.
 type printOutput struct {
-	FnName string   `json:"fn_name"`
-	Args   []string `json:"args"`
-	Msg    string   `json:"msg"`
-	Inner  any      `json:"inner"`
+	FnName string         `json:"fn_name"`
+	Args   []string       `json:"args"`
+	Msg    string         `json:"msg"`
+	Inner  any            `json:"inner"`
+	Stack  []Frame        `json:"stack,omitempty"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// Formatter renders a beterr debug tree (as built by toPrintOutput) to
+// bytes. Built-in implementations are JSONFormatter, PrettyJSONFormatter,
+// TextFormatter and LogfmtFormatter; SetDefaultFormatter changes the
+// package-wide default, and Wrap.EWithFormat picks one per call.
+type Formatter interface {
+	FormatError(o printOutput) ([]byte, error)
+}
+
+// JSONFormatter renders the compact JSON tree - the package's original,
+// still-default output.
+type JSONFormatter struct{}
+
+// FormatError implements Formatter.
+func (JSONFormatter) FormatError(o printOutput) ([]byte, error) {
+	return json.Marshal(o)
+}
+
+// PrettyJSONFormatter renders the same tree as JSONFormatter, indented for
+// human reading.
+type PrettyJSONFormatter struct{}
+
+// FormatError implements Formatter.
+func (PrettyJSONFormatter) FormatError(o printOutput) ([]byte, error) {
+	return json.MarshalIndent(o, "", "  ")
+}
+
+// TextFormatter renders an indented fn -> msg -> args tree, one block of
+// lines (fn name, then msg, args and fields) per level of the wrap chain.
+type TextFormatter struct{}
+
+// FormatError implements Formatter.
+func (TextFormatter) FormatError(o printOutput) ([]byte, error) {
+	var b strings.Builder
+	writeTextTree(&b, o, 0)
+	return []byte(strings.TrimSuffix(b.String(), "\n")), nil
+}
+
+func writeTextTree(b *strings.Builder, o printOutput, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(b, "%s%s\n", indent, o.FnName)
+	if o.Msg != "" {
+		fmt.Fprintf(b, "%s  msg: %s\n", indent, o.Msg)
+	}
+	if len(o.Args) > 0 {
+		fmt.Fprintf(b, "%s  args: %s\n", indent, strings.Join(o.Args, ", "))
+	}
+	for _, k := range sortedKeys(o.Fields) {
+		fmt.Fprintf(b, "%s  %s: %v\n", indent, k, o.Fields[k])
+	}
+	writeTextInner(b, o.Inner, depth+1)
+}
+
+func writeTextInner(b *strings.Builder, inner any, depth int) {
+	switch v := inner.(type) {
+	case printOutput:
+		writeTextTree(b, v, depth)
+	case []any:
+		for _, item := range v {
+			writeTextInner(b, item, depth)
+		}
+	case string:
+		if v != "" && v != "nil err" {
+			fmt.Fprintf(b, "%s%s\n", strings.Repeat("  ", depth), v)
+		}
+	}
+}
+
+// LogfmtFormatter renders the tree as flat logfmt key=value pairs, suitable
+// for log-aggregation pipelines. Nested wrap levels and joined causes are
+// flattened into dotted/indexed key prefixes (e.g. "inner.0.msg=...").
+type LogfmtFormatter struct{}
+
+// FormatError implements Formatter.
+func (LogfmtFormatter) FormatError(o printOutput) ([]byte, error) {
+	var b strings.Builder
+	writeLogfmt(&b, o, "")
+	return []byte(b.String()), nil
+}
+
+func writeLogfmt(b *strings.Builder, o printOutput, prefix string) {
+	writeLogfmtKV(b, prefix+"fn_name", o.FnName)
+	if o.Msg != "" {
+		writeLogfmtKV(b, prefix+"msg", o.Msg)
+	}
+	if len(o.Args) > 0 {
+		writeLogfmtKV(b, prefix+"args", strings.Join(o.Args, ","))
+	}
+	for _, k := range sortedKeys(o.Fields) {
+		writeLogfmtKV(b, prefix+"field."+k, fmt.Sprint(o.Fields[k]))
+	}
+	if len(o.Stack) > 0 {
+		writeLogfmtKV(b, prefix+"stack_depth", strconv.Itoa(len(o.Stack)))
+	}
+	writeLogfmtInner(b, o.Inner, prefix)
+}
+
+func writeLogfmtInner(b *strings.Builder, inner any, prefix string) {
+	switch v := inner.(type) {
+	case printOutput:
+		writeLogfmt(b, v, prefix+"inner.")
+	case []any:
+		for i, item := range v {
+			if po, ok := item.(printOutput); ok {
+				writeLogfmt(b, po, fmt.Sprintf("%sinner.%d.", prefix, i))
+				continue
+			}
+			writeLogfmtKV(b, fmt.Sprintf("%sinner.%d", prefix, i), fmt.Sprint(item))
+		}
+	case string:
+		if v != "" {
+			writeLogfmtKV(b, prefix+"inner", v)
+		}
+	}
+}
+
+func writeLogfmtKV(b *strings.Builder, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	if needsLogfmtQuote(value) {
+		value = strconv.Quote(value)
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	b.WriteString(value)
+}
+
+// needsLogfmtQuote reports whether value must be quoted to stay on a single
+// logfmt line and keep key/value boundaries unambiguous: any whitespace
+// (including newlines and tabs) or other control character, a quote, or the
+// "=" separator itself.
+func needsLogfmtQuote(value string) bool {
+	if strings.ContainsAny(value, "\"=") {
+		return true
+	}
+	return strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) >= 0
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// defaultFormatter is used by errors created via Wrap.E / Wrap.EJoin. Override
+// it with SetDefaultFormatter, or pick a formatter per-call with
+// Wrap.EWithFormat. It's an atomic.Pointer rather than a plain var because
+// SetDefaultFormatter and renderTree (reached from every error's Format) are
+// routinely called from different goroutines - logging one error while
+// another goroutine reconfigures the package-wide default.
+var defaultFormatter atomic.Pointer[Formatter]
+
+func init() {
+	var f Formatter = JSONFormatter{}
+	defaultFormatter.Store(&f)
+}
+
+// SetDefaultFormatter changes the Formatter used to render "%+v" output for
+// errors that weren't created with Wrap.EWithFormat. Safe to call
+// concurrently with error formatting.
+func SetDefaultFormatter(f Formatter) {
+	defaultFormatter.Store(&f)
+}
+
+// renderTree formats o with formatter (falling back to defaultFormatter when
+// nil), returning fallback if formatting fails.
+func renderTree(formatter Formatter, o printOutput, fallback string) string {
+	if formatter == nil {
+		formatter = *defaultFormatter.Load()
+	}
+	b, err := formatter.FormatError(o)
+	if err != nil {
+		return fallback
+	}
+	return string(b)
+}
+
+// kv is a single named field attached via Wrap.With.
+type kv struct {
+	Key   string
+	Value any
+}
+
+// Frame is a single resolved stack frame, as returned by (*beterrError).StackTrace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// beterrError is the concrete error type returned by Wrap.E. It keeps the
+// original cause reachable via Unwrap so that errors.Is, errors.As and %w
+// style chains work as expected, while still supporting the package's
+// structured JSON debug output through Format.
+type beterrError struct {
+	fnName    string
+	args      []any
+	msg       string
+	err       error
+	stack     []uintptr
+	fields    []kv
+	formatter Formatter // nil means "use defaultFormatter"
+}
+
+// callerFnName resolves the name of the function skip frames above
+// callerFnName itself (skip=2 from a function called directly by user code
+// names that user code, the same frame captureStack(3) starts at).
+func callerFnName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	return runtime.FuncForPC(pc).Name()
+}
+
+// captureStack records the call stack, skipping frames as runtime.Callers
+// does (1 is captureStack itself, so skip=3 starts at its caller's caller).
+func captureStack(skip int) []uintptr {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip, pcs)
+	return pcs[:n]
+}
+
+// sameStack reports whether a and b resolve to the same sequence of frames.
+func sameStack(a, b []uintptr) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stringifyArgs renders positional Wrap args for debug output, collapsing
+// context.Context values to the literal "ctx" rather than dumping their
+// (often unexported, cyclic) internals.
+func stringifyArgs(args []any) []string {
+	out := make([]string, 0, len(args))
+	for _, a := range args {
+		if _, ok := a.(context.Context); ok {
+			out = append(out, "ctx")
+			continue
+		}
+		out = append(out, StructString(a))
+	}
+	return out
+}
+
+// fieldsMap converts an ordered kv list into the map shape used by JSON
+// output, running each value through the same redaction pass as positional
+// args so Redact()-wrapped values and `beterr:"redact"`-tagged struct fields
+// attached via With are sanitized rather than leaking through untouched.
+func fieldsMap(fields []kv) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = redactForMarshal(reflect.ValueOf(f.Value))
+	}
+	return m
+}
+
+// fieldsShape reduces an ordered kv list to type/length shapes, mirroring
+// argShape for SafeString's PII-free report.
+func fieldsShape(fields []kv) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(fields))
+	for _, f := range fields {
+		m[f.Key] = argShape(f.Value)
+	}
+	return m
+}
+
+// resolveFrames turns captured program counters into resolved Frame values.
+func resolveFrames(stack []uintptr) []Frame {
+	if len(stack) == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(stack)
+	result := make([]Frame, 0, len(stack))
+	for {
+		f, more := frames.Next()
+		result = append(result, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// innerJSON renders err for the "inner" field of a debug tree: a nested tree
+// when it is (or wraps) a *beterrError/*beterrJoinError, otherwise its plain
+// message.
+func innerJSON(err error) any {
+	if err == nil {
+		return "nil err"
+	}
+	// One interface target, so errors.As stops at the first beterr error in
+	// the chain; a join is rendered whole rather than through one of its
+	// branches.
+	var tree interface{ toPrintOutput() printOutput }
+	if errors.As(err, &tree) {
+		return tree.toPrintOutput()
+	}
+	return err.Error()
+}
+
+// safeOutput is the PII-free report shape emitted by SafeString: function
+// names, stack traces and messages in full, but args and With() fields
+// reduced to their shape (type and length) rather than their contents.
+type safeOutput struct {
+	FnName string            `json:"fn_name"`
+	Args   []string          `json:"args"`
+	Msg    string            `json:"msg"`
+	Stack  []Frame           `json:"stack,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+	Inner  any               `json:"inner,omitempty"`
+}
+
+// safeInner renders err for the "inner" field of a SafeString report: a
+// nested safe report when it is (or wraps) a *beterrError/*beterrJoinError,
+// otherwise just its Go type name, deliberately never its message.
+func safeInner(err error) any {
+	if err == nil {
+		return nil
+	}
+	var report interface{ toSafeOutput() safeOutput }
+	if errors.As(err, &report) {
+		return report.toSafeOutput()
+	}
+	return reflect.TypeOf(err).String()
+}
+
+// Error returns a compact, single-line message. Use "%+v" (via fmt.Formatter)
+// to render the full structured JSON debug tree instead.
+func (e *beterrError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.fnName)
+	if e.msg != "" {
+		b.WriteString(": ")
+		b.WriteString(e.msg)
+	}
+	if e.err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the wrapped cause, enabling errors.Is/errors.As and the
+// standard library's error-chain traversal.
+func (e *beterrError) Unwrap() error {
+	return e.err
+}
+
+// effectiveStack returns e.stack, or - if wrapping didn't record a new trace
+// because it matched the wrapped cause's trace (see Wrap.E) - the nearest
+// cause's own effective stack.
+func (e *beterrError) effectiveStack() []uintptr {
+	if len(e.stack) > 0 {
+		return e.stack
+	}
+	var inner *beterrError
+	if errors.As(e.err, &inner) {
+		return inner.effectiveStack()
+	}
+	return nil
+}
+
+// StackTrace returns the resolved stack frames captured when this error was
+// created. If wrapping didn't record a new trace because it matched the
+// wrapped cause's trace (see Wrap.E), it returns the cause's trace instead.
+func (e *beterrError) StackTrace() []Frame {
+	return resolveFrames(e.effectiveStack())
+}
+
+// MarshalJSON implements json.Marshaler, rendering the same debug tree used
+// by "%+v" formatting, stack trace included.
+func (e *beterrError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toPrintOutput())
+}
+
+// toPrintOutput builds the JSON-serializable debug tree for this error,
+// recursing into a wrapped *beterrError so nested Wrap.E calls render as a
+// nested tree rather than a re-parsed string.
+func (e *beterrError) toPrintOutput() printOutput {
+	return printOutput{
+		FnName: e.fnName,
+		Args:   stringifyArgs(e.args),
+		Msg:    e.msg,
+		Stack:  e.StackTrace(),
+		Fields: fieldsMap(e.fields),
+		Inner:  innerJSON(e.err),
+	}
+}
+
+// toSafeOutput builds the PII-free report tree for this error.
+func (e *beterrError) toSafeOutput() safeOutput {
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		args[i] = argShape(a)
+	}
+	return safeOutput{
+		FnName: e.fnName,
+		Args:   args,
+		Msg:    e.msg,
+		Stack:  e.StackTrace(),
+		Fields: fieldsShape(e.fields),
+		Inner:  safeInner(e.err),
+	}
+}
+
+// SafeString renders a PII-free report containing function names, stack
+// traces, messages and arg shapes (types/lengths, not contents) - safe to
+// ship to external error trackers. Error() and "%+v" keep full detail for
+// local logs.
+func (e *beterrError) SafeString() string {
+	return StructString(e.toSafeOutput())
+}
+
+// LogValue implements slog.LogValuer so that slog.Error("...", "err", err)
+// emits the function name, message, args and all fields attached via
+// Wrap.With as structured attributes instead of a single opaque string.
+func (e *beterrError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4+len(e.fields))
+	attrs = append(attrs, slog.String("fn_name", e.fnName))
+	attrs = append(attrs, slog.String("msg", e.msg))
+	if len(e.args) > 0 {
+		attrs = append(attrs, slog.Any("args", stringifyArgs(e.args)))
+	}
+	for _, f := range e.fields {
+		attrs = append(attrs, slog.Any(f.Key, redactForMarshal(reflect.ValueOf(f.Value))))
+	}
+	if e.err != nil {
+		attrs = append(attrs, slog.Any("inner", e.err))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements fmt.Formatter. "%+v" prints the debug tree rendered by
+// this error's Formatter (JSON by default - see SetDefaultFormatter and
+// Wrap.EWithFormat); "%s" and "%v" print the compact single-line message
+// from Error().
+func (e *beterrError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprint(f, renderTree(e.formatter, e.toPrintOutput(), e.Error()))
+			return
+		}
+		fmt.Fprint(f, e.Error())
+	case 's':
+		fmt.Fprint(f, e.Error())
+	default:
+		fmt.Fprint(f, e.Error())
+	}
+}
+
+// beterrJoinError is the error type returned by Wrap.EJoin. It holds multiple
+// causes (Go 1.20 errors.Join semantics) so that errors.Is and errors.As
+// traverse every branch, and so that each branch renders independently in
+// the JSON debug tree instead of being flattened into one string.
+type beterrJoinError struct {
+	fnName    string
+	args      []any
+	msg       string
+	errs      []error
+	stack     []uintptr
+	fields    []kv
+	formatter Formatter // nil means "use defaultFormatter"
+}
+
+// Error returns a compact, single-line message joining every cause's message.
+func (e *beterrJoinError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, c := range e.errs {
+		msgs[i] = c.Error()
+	}
+	var b strings.Builder
+	b.WriteString(e.fnName)
+	if e.msg != "" {
+		b.WriteString(": ")
+		b.WriteString(e.msg)
+	}
+	b.WriteString(": ")
+	b.WriteString(strings.Join(msgs, "; "))
+	return b.String()
+}
+
+// Unwrap returns every non-nil cause, enabling errors.Is/errors.As to
+// traverse each branch independently per Go 1.20 multi-error semantics.
+func (e *beterrJoinError) Unwrap() []error {
+	return e.errs
+}
+
+// Causes returns the errors this error joins together.
+func (e *beterrJoinError) Causes() []error {
+	return e.errs
+}
+
+// StackTrace returns the resolved stack frames captured when this error was
+// created.
+func (e *beterrJoinError) StackTrace() []Frame {
+	return resolveFrames(e.stack)
+}
+
+// MarshalJSON implements json.Marshaler, rendering the same debug tree used
+// by "%+v" formatting.
+func (e *beterrJoinError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toPrintOutput())
+}
+
+// toPrintOutput builds the JSON-serializable debug tree for this error. Inner
+// is an array with one entry per joined cause, each rendered as its own
+// (possibly nested) tree rather than flattened into a single string.
+func (e *beterrJoinError) toPrintOutput() printOutput {
+	inner := make([]any, len(e.errs))
+	for i, c := range e.errs {
+		inner[i] = innerJSON(c)
+	}
+	return printOutput{
+		FnName: e.fnName,
+		Args:   stringifyArgs(e.args),
+		Msg:    e.msg,
+		Stack:  e.StackTrace(),
+		Fields: fieldsMap(e.fields),
+		Inner:  inner,
+	}
+}
+
+// toSafeOutput builds the PII-free report tree for this error, one entry per
+// joined cause.
+func (e *beterrJoinError) toSafeOutput() safeOutput {
+	args := make([]string, len(e.args))
+	for i, a := range e.args {
+		args[i] = argShape(a)
+	}
+	inner := make([]any, len(e.errs))
+	for i, c := range e.errs {
+		inner[i] = safeInner(c)
+	}
+	return safeOutput{
+		FnName: e.fnName,
+		Args:   args,
+		Msg:    e.msg,
+		Stack:  e.StackTrace(),
+		Fields: fieldsShape(e.fields),
+		Inner:  inner,
+	}
+}
+
+// SafeString renders a PII-free report, mirroring (*beterrError).SafeString.
+func (e *beterrJoinError) SafeString() string {
+	return StructString(e.toSafeOutput())
+}
+
+// LogValue implements slog.LogValuer, mirroring (*beterrError).LogValue.
+func (e *beterrJoinError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4+len(e.fields))
+	attrs = append(attrs, slog.String("fn_name", e.fnName))
+	attrs = append(attrs, slog.String("msg", e.msg))
+	if len(e.args) > 0 {
+		attrs = append(attrs, slog.Any("args", stringifyArgs(e.args)))
+	}
+	for _, f := range e.fields {
+		attrs = append(attrs, slog.Any(f.Key, redactForMarshal(reflect.ValueOf(f.Value))))
+	}
+	if len(e.errs) > 0 {
+		causes := make([]any, len(e.errs))
+		for i, c := range e.errs {
+			causes[i] = c
+		}
+		attrs = append(attrs, slog.Any("inner", causes))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Format implements fmt.Formatter, mirroring (*beterrError).Format.
+func (e *beterrJoinError) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			fmt.Fprint(f, renderTree(e.formatter, e.toPrintOutput(), e.Error()))
+			return
+		}
+		fmt.Fprint(f, e.Error())
+	case 's':
+		fmt.Fprint(f, e.Error())
+	default:
+		fmt.Fprint(f, e.Error())
+	}
 }
 
 // Wrap provides debugging functionality with argument tracking.
@@ -23,43 +636,95 @@ type printOutput struct {
 type Wrap struct {
 	// A holds arguments to be included in debug output
 	A []any
+
+	// fields holds named key/value context attached via With, included in
+	// error output under a "fields" key in addition to the positional A args.
+	fields []kv
+}
+
+// With returns a copy of w with an additional named field attached. Fields
+// are included in JSON output under "fields" and as structured attributes
+// when the resulting error is logged through slog. Positional W(args...)
+// continues to work unchanged alongside With.
+func (w *Wrap) With(key string, value any) *Wrap {
+	fields := make([]kv, len(w.fields), len(w.fields)+1)
+	copy(fields, w.fields)
+	fields = append(fields, kv{Key: key, Value: value})
+	return &Wrap{A: w.A, fields: fields}
 }
 
 // E formats an error with debugging context including function name, arguments, and message.
 // It wraps the original error with structured debugging information that can be chained.
+// The returned error supports errors.Is, errors.As and errors.Unwrap against err,
+// and renders its full debug tree via "%+v".
 func (w *Wrap) E(err error, msg ...string) error {
-	m := strings.Join(msg, " ")
-	pc, _, _, ok := runtime.Caller(1)
-	fnName := "unknown"
-	if ok {
-		fnName = runtime.FuncForPC(pc).Name()
+	fnName := callerFnName(2)
+
+	stack := captureStack(3)
+	var inner *beterrError
+	if errors.As(err, &inner) && sameStack(stack, inner.effectiveStack()) {
+		// The cause already carries this exact trace (e.g. wrapping in a
+		// tight loop with a fixed call site) - don't store a duplicate.
+		stack = nil
 	}
-	errStr := "nil err"
-	if err != nil {
-		errStr = err.Error()
+
+	return &beterrError{
+		fnName: fnName,
+		args:   w.A,
+		msg:    strings.Join(msg, " "),
+		err:    err,
+		stack:  stack,
+		fields: w.fields,
 	}
-	o := printOutput{
-		FnName: fnName,
-		Args:   []string{},
-		Msg:    m,
-		Inner:  errStr,
+}
+
+// EWithFormat behaves like E but pins the Formatter used to render "%+v"
+// output for the returned error, regardless of any later SetDefaultFormatter
+// call.
+func (w *Wrap) EWithFormat(formatter Formatter, err error, msg ...string) error {
+	fnName := callerFnName(2)
+
+	stack := captureStack(3)
+	var inner *beterrError
+	if errors.As(err, &inner) && sameStack(stack, inner.effectiveStack()) {
+		stack = nil
 	}
 
-	// See if we can unmarshal inner into PrintOutput
-	var prevO printOutput
-	myErr := json.Unmarshal([]byte(err.Error()), &prevO)
-	if myErr == nil {
-		o.Inner = prevO
+	return &beterrError{
+		fnName:    fnName,
+		args:      w.A,
+		msg:       strings.Join(msg, " "),
+		err:       err,
+		stack:     stack,
+		fields:    w.fields,
+		formatter: formatter,
 	}
+}
 
-	for _, c := range w.A {
-		if _, ok := c.(context.Context); ok {
-			o.Args = append(o.Args, "ctx")
-			continue
+// EJoin wraps multiple causes at once (nil entries are dropped), producing
+// an error whose Unwrap() []error returns every cause per Go 1.20
+// errors.Join semantics, so errors.Is/errors.As traverse each branch.
+// Like errors.Join, EJoin returns nil if every argument is nil.
+func (w *Wrap) EJoin(errs ...error) error {
+	nonNil := make([]error, 0, len(errs))
+	for _, e := range errs {
+		if e != nil {
+			nonNil = append(nonNil, e)
 		}
-		o.Args = append(o.Args, StructString(c))
 	}
-	return fmt.Errorf("%s", StructString(o))
+	if len(nonNil) == 0 {
+		return nil
+	}
+
+	fnName := callerFnName(2)
+
+	return &beterrJoinError{
+		fnName: fnName,
+		args:   w.A,
+		errs:   nonNil,
+		stack:  captureStack(3),
+		fields: w.fields,
+	}
 }
 
 // W creates a new Wrap instance with the provided arguments.
@@ -73,9 +738,200 @@ func W(args ...any) *Wrap {
 	return &Wrap{A: args}
 }
 
-// StructString converts any value to a JSON string representation.
+// redacted marks a value as sensitive. Use Redact to create one; it is
+// replaced with the literal "<redacted>" wherever beterr renders values.
+type redacted struct {
+	v any
+}
+
+var redactedType = reflect.TypeOf(redacted{})
+
+// Redact marks v as sensitive so StructString (and therefore Wrap.E's args,
+// StackTrace-adjacent JSON output, etc.) renders it as "<redacted>" instead
+// of its real contents.
+//
+// Example usage:
+//   w := W(userID, Redact(password), Redact(token))
+func Redact(v any) any {
+	return redacted{v: v}
+}
+
+// anyType is reflect.TypeOf for the empty interface, used by redactStruct to
+// build fields that can hold an already-redacted value of any shape.
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// typeNeedsRedaction reports whether a value of type t can, transitively
+// through pointers, slices, arrays, maps and structs, contain a
+// Redact()-wrapped value or a field tagged `beterr:"redact"`. Interface
+// fields are assumed to need it, since their dynamic type isn't known until
+// a value is in hand. redactForMarshal uses this to leave branches that
+// can't possibly need redaction completely untouched, so json.Marshal sees
+// their original concrete type and applies its own tag semantics (name,
+// omitempty, ",string", field order) exactly as it would without beterr in
+// the picture.
+func typeNeedsRedaction(t reflect.Type) bool {
+	return typeNeedsRedactionVisiting(t, map[reflect.Type]bool{})
+}
+
+// typeNeedsRedactionVisiting does the work for typeNeedsRedaction, tracking
+// struct types already on the current path so a self-referential type (a
+// linked list or tree node, say) can't recurse forever - once a struct type
+// is seen again, its own fields have already been checked higher up the
+// call stack, so it contributes nothing further here.
+func typeNeedsRedactionVisiting(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return typeNeedsRedactionVisiting(t.Elem(), visiting)
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if t == redactedType {
+			return true
+		}
+		if visiting[t] {
+			return false
+		}
+		visiting[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue // unexported
+			}
+			if tag, ok := f.Tag.Lookup("beterr"); ok && tag == "redact" {
+				return true
+			}
+			if typeNeedsRedactionVisiting(f.Type, visiting) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// redactForMarshal walks v via reflection, producing a value safe to pass to
+// json.Marshal with Redact()-wrapped values and struct fields tagged
+// `beterr:"redact"` replaced by "<redacted>". A branch that can't contain
+// either (per typeNeedsRedaction) is returned via v.Interface() untouched,
+// so its original type - and therefore json.Marshal's handling of its json
+// tags - is unaffected by redaction happening elsewhere in the tree.
+func redactForMarshal(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == redactedType {
+		return "<redacted>"
+	}
+	if !typeNeedsRedaction(v.Type()) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v.Interface()
+		}
+		return redactForMarshal(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactForMarshal(v.Elem())
+	case reflect.Struct:
+		return redactStruct(v)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v.Interface()
+		}
+		out := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out[i] = redactForMarshal(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v.Interface()
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = redactForMarshal(iter.Value())
+		}
+		return out
+	default:
+		return v.Interface()
+	}
+}
+
+// redactStruct rebuilds v's type via reflect.StructOf, field by field: a
+// field tagged `beterr:"redact"` is retyped to string and given the value
+// "<redacted>"; a field whose type needs further redaction is retyped to
+// any and recursed into; every other field keeps its original type, value
+// and json tag completely untouched. Only called when
+// typeNeedsRedaction(v.Type()) is true, so the result always differs from v
+// in at least one field.
+func redactStruct(v reflect.Value) any {
+	t := v.Type()
+	fields := make([]reflect.StructField, 0, t.NumField())
+	values := make([]any, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported; json.Marshal would skip it too
+		}
+		switch {
+		case f.Tag.Get("beterr") == "redact":
+			fields = append(fields, reflect.StructField{Name: f.Name, Type: reflect.TypeOf(""), Tag: f.Tag})
+			values = append(values, "<redacted>")
+		case typeNeedsRedaction(f.Type):
+			fields = append(fields, reflect.StructField{Name: f.Name, Type: anyType, Tag: f.Tag})
+			values = append(values, redactForMarshal(v.Field(i)))
+		default:
+			fields = append(fields, f)
+			values = append(values, v.Field(i).Interface())
+		}
+	}
+
+	out := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, val := range values {
+		if val == nil {
+			continue // leave the zero value (nil) in the interface{} field
+		}
+		out.Field(i).Set(reflect.ValueOf(val))
+	}
+	return out.Interface()
+}
+
+// argShape describes a value's type and length without revealing its
+// contents, e.g. "string(len=8)" or "int". Used by SafeString to report
+// enough shape information to be useful without leaking PII.
+func argShape(v any) string {
+	if v == nil {
+		return "nil"
+	}
+	if _, ok := v.(redacted); ok {
+		return "redacted"
+	}
+	rv := reflect.ValueOf(v)
+	t := rv.Type()
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s(len=%d)", t.String(), rv.Len())
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return fmt.Sprintf("%s(nil)", t.String())
+		}
+		return t.String()
+	default:
+		return t.String()
+	}
+}
+
+// StructString converts any value to a JSON string representation, redacting
+// Redact()-wrapped values and fields tagged `beterr:"redact"` along the way.
 // If JSON marshaling fails, it falls back to the default string format.
 func StructString(v any) string {
+	v = redactForMarshal(reflect.ValueOf(v))
 	s, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Sprintf("%+v", v) // Fallback to default string representation
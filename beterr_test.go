@@ -0,0 +1,307 @@
+package beterr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestErrorsIsAsChainTraversal verifies that Unwrap() error alone is enough
+// for errors.Is/errors.As to find (or correctly miss) a sentinel through a
+// deep chain of w.E wraps, without the quadratic blowup that custom Is/As
+// methods delegating to errors.Is/errors.As on the same cause used to cause.
+func TestErrorsIsAsChainTraversal(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	unrelated := errors.New("unrelated")
+
+	w := W()
+	var err error = sentinel
+	const depth = 200
+	for i := 0; i < depth; i++ {
+		err = w.E(err, "wrap")
+	}
+
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("errors.Is should find sentinel through the chain")
+	}
+
+	start := time.Now()
+	if errors.Is(err, unrelated) {
+		t.Fatalf("errors.Is should not match an unrelated sentinel")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("errors.Is miss took %s at depth %d, want well under 1s", elapsed, depth)
+	}
+
+	var target *customErr
+	custom := &customErr{}
+	err = w.E(custom, "wrap")
+	if !errors.As(err, &target) {
+		t.Fatalf("errors.As should find the wrapped *customErr")
+	}
+	if target != custom {
+		t.Fatalf("errors.As extracted the wrong value")
+	}
+}
+
+type customErr struct{}
+
+func (*customErr) Error() string { return "custom" }
+
+// TestStackDedupAcrossMultipleWraps verifies that the "don't store a
+// duplicate trace" optimization in Wrap.E compares against the cause's
+// effective trace (walking through already-deduped causes), not just its raw
+// stack field - otherwise dedup only holds for one level before re-triggering.
+func TestStackDedupAcrossMultipleWraps(t *testing.T) {
+	w := W()
+	var err error = errors.New("base")
+	lengths := make([]int, 3)
+	for i := 0; i < 3; i++ {
+		err = w.E(err, "loop")
+		be, ok := err.(*beterrError)
+		if !ok {
+			t.Fatalf("expected *beterrError, got %T", err)
+		}
+		lengths[i] = len(be.stack)
+	}
+
+	if lengths[0] == 0 {
+		t.Fatalf("first wrap should capture a stack, got length 0")
+	}
+	if lengths[1] != 0 || lengths[2] != 0 {
+		t.Fatalf("wraps from the same call site after the first should dedup to an empty stack, got %v", lengths)
+	}
+
+	if trace := err.(*beterrError).StackTrace(); len(trace) == 0 {
+		t.Fatalf("StackTrace() should still resolve frames via the effective trace")
+	}
+}
+
+// TestEJoinAllNilReturnsNil verifies EJoin matches errors.Join's nil-in/nil-out
+// semantics so the usual "if err := w.EJoin(a, b); err != nil" idiom doesn't
+// see a spurious error when every joined cause is nil.
+func TestEJoinAllNilReturnsNil(t *testing.T) {
+	w := W()
+	if err := w.EJoin(nil, nil); err != nil {
+		t.Fatalf("EJoin(nil, nil) should return nil, got %v", err)
+	}
+	if err := w.EJoin(); err != nil {
+		t.Fatalf("EJoin() should return nil, got %v", err)
+	}
+
+	sentinel := errors.New("boom")
+	if err := w.EJoin(nil, sentinel); err == nil {
+		t.Fatalf("EJoin should return non-nil when at least one cause is non-nil")
+	} else if !errors.Is(err, sentinel) {
+		t.Fatalf("EJoin result should wrap the non-nil cause")
+	}
+}
+
+type creds struct {
+	User string
+	Pass string `beterr:"redact"`
+}
+
+// TestWithFieldRedaction verifies that values attached via Wrap.With go
+// through the same redaction pass as positional args: a `beterr:"redact"`
+// tagged struct field and a Redact()-wrapped value must both render as
+// "<redacted>" in the full %+v/JSON tree, and the field's shape (not
+// contents) must still show up in SafeString's PII-free report.
+func TestWithFieldRedaction(t *testing.T) {
+	w := W().With("creds", creds{User: "alice", Pass: "hunter2"}).With("token", Redact("s3cr3t"))
+	err := w.E(errors.New("boom"), "failed").(*beterrError)
+
+	tree := err.toPrintOutput()
+	credsJSON, jsonErr := json.Marshal(tree.Fields["creds"])
+	if jsonErr != nil {
+		t.Fatalf("marshaling creds field: %v", jsonErr)
+	}
+	var credsField map[string]any
+	if jsonErr := json.Unmarshal(credsJSON, &credsField); jsonErr != nil {
+		t.Fatalf("unmarshaling creds field: %v", jsonErr)
+	}
+	if credsField["User"] != "alice" {
+		t.Fatalf("non-redacted field should pass through unchanged, got %#v", credsField["User"])
+	}
+	if credsField["Pass"] != "<redacted>" {
+		t.Fatalf("beterr:\"redact\" tagged field should be redacted, got %#v", credsField["Pass"])
+	}
+	if tree.Fields["token"] != "<redacted>" {
+		t.Fatalf("Redact()-wrapped field should render as \"<redacted>\", got %#v", tree.Fields["token"])
+	}
+
+	rendered := StructString(tree)
+	if strings.Contains(rendered, "hunter2") || strings.Contains(rendered, "s3cr3t") {
+		t.Fatalf("rendered tree leaked sensitive field content: %s", rendered)
+	}
+
+	safe := err.toSafeOutput()
+	if safe.Fields["creds"] == "" {
+		t.Fatalf("SafeString report should include a shape entry for With() fields")
+	}
+	if safe.Fields["token"] != "redacted" {
+		t.Fatalf("SafeString report should mark Redact()-wrapped fields as redacted, got %q", safe.Fields["token"])
+	}
+}
+
+type reqWithExtra struct {
+	ID    string `json:"id"`
+	Extra string `json:"extra,omitempty"`
+}
+
+// TestStructStringPreservesJSONTagSemantics verifies that StructString only
+// touches branches that actually need redaction: a struct with no
+// Redact()-wrapped values and no `beterr:"redact"` fields must marshal
+// exactly as json.Marshal would, honoring "omitempty" and declaration order
+// rather than being reshaped into an alphabetized map.
+func TestStructStringPreservesJSONTagSemantics(t *testing.T) {
+	got := StructString(reqWithExtra{ID: "abc"})
+	want := `{"id":"abc"}`
+	if got != want {
+		t.Fatalf("StructString(%#v) = %s, want %s (omitempty/order should match json.Marshal)", reqWithExtra{ID: "abc"}, got, want)
+	}
+
+	direct, err := json.Marshal(reqWithExtra{ID: "abc", Extra: "x"})
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if got := StructString(reqWithExtra{ID: "abc", Extra: "x"}); got != string(direct) {
+		t.Fatalf("StructString(%#v) = %s, want %s to match json.Marshal exactly", reqWithExtra{ID: "abc", Extra: "x"}, got, direct)
+	}
+}
+
+// TestStructStringRedactsNestedTaggedField verifies that a struct embedding
+// a `beterr:"redact"` tagged field still gets redacted even though the
+// outer struct itself carries no tag - typeNeedsRedaction must recurse into
+// field types, not just the top-level type.
+func TestStructStringRedactsNestedTaggedField(t *testing.T) {
+	type wrapper struct {
+		Label string `json:"label"`
+		Creds creds  `json:"creds"`
+	}
+	got := StructString(wrapper{Label: "x", Creds: creds{User: "alice", Pass: "hunter2"}})
+	if strings.Contains(got, "hunter2") {
+		t.Fatalf("nested beterr:\"redact\" field leaked: %s", got)
+	}
+	if !strings.Contains(got, `"label":"x"`) {
+		t.Fatalf("untouched sibling field should marshal unchanged, got %s", got)
+	}
+}
+
+type selfRefNode struct {
+	Next   *selfRefNode
+	Secret string `beterr:"redact"`
+}
+
+// TestStructStringHandlesSelfReferentialType verifies that a
+// self-referential struct type (e.g. a linked-list/tree node with a field
+// pointing back to its own type) doesn't send typeNeedsRedaction into
+// infinite recursion - it must terminate by type, not by following the
+// (possibly nil, possibly cyclic) runtime value.
+func TestStructStringHandlesSelfReferentialType(t *testing.T) {
+	got := StructString(selfRefNode{Secret: "hunter2"})
+	if strings.Contains(got, "hunter2") {
+		t.Fatalf("beterr:\"redact\" field on a self-referential type leaked: %s", got)
+	}
+}
+
+// TestLogfmtQuotesControlCharacters verifies that LogfmtFormatter quotes a
+// value containing a raw newline (or other control character) even when it
+// has no space, quote or "=" - otherwise a single error renders as multiple
+// garbled lines in a line-oriented log pipeline.
+func TestLogfmtQuotesControlCharacters(t *testing.T) {
+	w := W()
+	err := w.EWithFormat(LogfmtFormatter{}, errors.New("base\nbad"), "word1\nword2")
+
+	rendered := fmt.Sprintf("%+v", err)
+	lines := strings.Split(rendered, "\n")
+	if len(lines) != 1 {
+		t.Fatalf("logfmt output should be a single line, got %d lines: %q", len(lines), rendered)
+	}
+	if !strings.Contains(rendered, `msg="word1\nword2"`) {
+		t.Fatalf("newline-containing value should be quoted and escaped, got %q", rendered)
+	}
+}
+
+// TestFormattersRenderExpectedShape is a basic sanity check for each
+// built-in Formatter's output shape, so a future change to one doesn't
+// silently break another.
+func TestFormattersRenderExpectedShape(t *testing.T) {
+	w := W(42)
+	err := errors.New("base")
+
+	jsonErr := w.EWithFormat(JSONFormatter{}, err, "failed")
+	if got := fmt.Sprintf("%+v", jsonErr); !strings.HasPrefix(got, "{") || !strings.Contains(got, `"msg":"failed"`) {
+		t.Fatalf("JSONFormatter output missing expected shape: %s", got)
+	}
+
+	prettyErr := w.EWithFormat(PrettyJSONFormatter{}, err, "failed")
+	if got := fmt.Sprintf("%+v", prettyErr); !strings.Contains(got, "\n") || !strings.Contains(got, `"msg": "failed"`) {
+		t.Fatalf("PrettyJSONFormatter output missing expected shape: %s", got)
+	}
+
+	textErr := w.EWithFormat(TextFormatter{}, err, "failed")
+	if got := fmt.Sprintf("%+v", textErr); !strings.Contains(got, "msg: failed") || !strings.Contains(got, "args: 42") {
+		t.Fatalf("TextFormatter output missing expected shape: %s", got)
+	}
+
+	logfmtErr := w.EWithFormat(LogfmtFormatter{}, err, "failed")
+	if got := fmt.Sprintf("%+v", logfmtErr); !strings.Contains(got, "msg=failed") || !strings.Contains(got, "inner=base") {
+		t.Fatalf("LogfmtFormatter output missing expected shape: %s", got)
+	}
+}
+
+// TestSetDefaultFormatterConcurrent exercises SetDefaultFormatter and error
+// formatting from many goroutines at once. Run with -race: before
+// defaultFormatter became an atomic.Pointer, this reliably tripped the race
+// detector.
+func TestSetDefaultFormatterConcurrent(t *testing.T) {
+	defer SetDefaultFormatter(JSONFormatter{})
+
+	w := W()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			if i%2 == 0 {
+				SetDefaultFormatter(TextFormatter{})
+			} else {
+				SetDefaultFormatter(JSONFormatter{})
+			}
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		err := w.E(errors.New("base"), "failed")
+		_ = fmt.Sprintf("%+v", err)
+	}
+	<-done
+}
+
+// TestWithAndLogValueSlogOutput verifies that fields attached via With show
+// up as structured slog attributes (not just in the JSON tree), with
+// Redact()-wrapped values still redacted.
+func TestWithAndLogValueSlogOutput(t *testing.T) {
+	w := W().With("user_id", 42).With("token", Redact("s3cr3t"))
+	err := w.E(errors.New("boom"), "failed").(*beterrError)
+
+	group := err.LogValue()
+	if group.Kind() != slog.KindGroup {
+		t.Fatalf("LogValue() should return a group, got kind %v", group.Kind())
+	}
+
+	attrs := make(map[string]slog.Value, len(group.Group()))
+	for _, a := range group.Group() {
+		attrs[a.Key] = a.Value
+	}
+	if attrs["user_id"].String() != "42" {
+		t.Fatalf("user_id attribute = %#v, want 42", attrs["user_id"].Any())
+	}
+	if attrs["token"].Any() != "<redacted>" {
+		t.Fatalf("token attribute = %#v, want \"<redacted>\"", attrs["token"].Any())
+	}
+}